@@ -0,0 +1,68 @@
+// Package events records what happens to books - creates, updates,
+// deletes, checkouts, returns - as an append-only audit log, and can fan
+// those events out to other destinations (stdout, a file, a message bus)
+// through the pluggable Sink interface.
+package events
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// Type enumerates the kinds of events recorded against a book.
+type Type string
+
+const (
+	EventCreated    Type = "created"
+	EventUpdated    Type = "updated"
+	EventDeleted    Type = "deleted"
+	EventCheckedOut Type = "checked_out"
+	EventReturned   Type = "returned"
+)
+
+// BookEvent is one entry in a book's history. The xml tags mirror the
+// snake_case convention the Book/Envelope XML representation already
+// uses, so GET /events and GET /books/:id/events render consistently
+// whichever format a client negotiates.
+type BookEvent struct {
+	XMLName   xml.Name        `json:"-" xml:"event"`
+	ID        string          `json:"id" xml:"id,attr"`
+	BookID    string          `json:"book_id" xml:"book_id"`
+	EventType Type            `json:"event_type" xml:"event_type"`
+	ActorID   *string         `json:"actor_id,omitempty" xml:"actor_id,omitempty"` // nil until the API has authenticated callers
+	Timestamp time.Time       `json:"timestamp" xml:"timestamp"`
+	Payload   json.RawMessage `json:"payload,omitempty" xml:"payload,omitempty"` // a JSON-encoded snapshot of the book after the event (for EventDeleted, as it was just before removal)
+}
+
+// Sink receives every BookEvent as it's recorded. Implementations decide
+// what to do with it - append to a log, print it, publish it - and return
+// the event back, stamped with any fields they assigned (e.g. Log.Emit
+// assigns ID), so a sink running later in a fan-out sees the same event
+// earlier sinks did.
+type Sink interface {
+	Emit(e BookEvent) (BookEvent, error)
+}
+
+// MultiSink fans one event out to several sinks in order, threading each
+// sink's returned (possibly stamped) event into the next so every sink
+// after the first sees whichever fields an earlier one assigned - in
+// particular, the Log sink's generated ID. A failing sink doesn't stop the
+// others from receiving the event; the first error (if any) is returned
+// to the caller.
+type MultiSink []Sink
+
+func (m MultiSink) Emit(e BookEvent) (BookEvent, error) {
+	var firstErr error
+	for _, sink := range m {
+		stamped, err := sink.Emit(e)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		e = stamped
+	}
+	return e, firstErr
+}
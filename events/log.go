@@ -0,0 +1,133 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Log is an in-memory, append-only record of every BookEvent, queryable by
+// book or across books. It implements Sink itself, so recording into it is
+// just another fan-out target alongside stdout/file/bus sinks.
+type Log struct {
+	mu     sync.RWMutex
+	events []BookEvent
+	nextID uint64
+}
+
+// NewLog returns an empty Log.
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Emit assigns e an ID, appends it to the log, and returns the stamped
+// event so callers fanning out to other sinks (see MultiSink) can pass on
+// the same ID instead of each sink minting its own.
+func (l *Log) Emit(e BookEvent) (BookEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	e.ID = fmt.Sprintf("evt-%d", l.nextID)
+	l.events = append(l.events, e)
+	return e, nil
+}
+
+// appendRaw appends e as-is, preserving its existing ID - used by
+// ReadLogFile to restore previously-emitted events without renumbering them.
+func (l *Log) appendRaw(e BookEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.nextID++
+	l.events = append(l.events, e)
+}
+
+// ForBook returns every event recorded for bookID, oldest first.
+func (l *Log) ForBook(bookID string) []BookEvent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var out []BookEvent
+	for _, e := range l.events {
+		if e.BookID == bookID {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Query filters cross-book: Since (if non-zero) drops events older than
+// it, Type (if non-empty) restricts to one event type, and Offset/Limit
+// paginate the (already filtered) result.
+type Query struct {
+	Since  time.Time
+	Type   Type // empty means "any"
+	Offset int
+	Limit  int
+}
+
+// Query returns the events matching q, oldest first.
+func (l *Log) Query(q Query) []BookEvent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var matched []BookEvent
+	for _, e := range l.events {
+		if !q.Since.IsZero() && e.Timestamp.Before(q.Since) {
+			continue
+		}
+		if q.Type != "" && e.EventType != q.Type {
+			continue
+		}
+		matched = append(matched, e)
+	}
+
+	start := q.Offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := len(matched)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+	}
+	return matched[start:end]
+}
+
+// All returns every recorded event, oldest first - used by ReplayEvents.
+func (l *Log) All() []BookEvent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make([]BookEvent, len(l.events))
+	copy(out, l.events)
+	return out
+}
+
+// ReadLogFile reads newline-delimited JSON events previously written by a
+// FileSink at path and returns them as a populated Log. A missing file is
+// not an error - it just means no history exists yet.
+func ReadLogFile(path string) (*Log, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewLog(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("events: open log file: %w", err)
+	}
+	defer f.Close()
+
+	log := NewLog()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e BookEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("events: decode log line: %w", err)
+		}
+		log.appendRaw(e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("events: read log file: %w", err)
+	}
+	return log, nil
+}
@@ -0,0 +1,46 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/abdullah-hamada7/Go-REST-API/store"
+)
+
+// ReplayEvents rebuilds s's contents by replaying every event in log, in
+// order. Each non-delete event's Payload is a JSON-encoded snapshot of the
+// book right after that event, so replay just needs to create-or-update
+// using that snapshot - it doesn't need to interpret event-specific deltas.
+// A delete event's Payload (the book as it was before removal) is not read;
+// replay deletes by BookID.
+//
+// This is what lets the in-memory backend survive a restart when it's
+// configured with a file sink: start the new process, read the file with
+// ReadLogFile, then call ReplayEvents against a fresh store.
+func ReplayEvents(s store.Store, log *Log) error {
+	for _, e := range log.All() {
+		if e.EventType == EventDeleted {
+			if err := s.Delete(e.BookID); err != nil && !errors.Is(err, store.ErrNotFound) {
+				return fmt.Errorf("events: replay delete %s: %w", e.BookID, err)
+			}
+			continue
+		}
+
+		var b store.Book
+		if err := json.Unmarshal(e.Payload, &b); err != nil {
+			return fmt.Errorf("events: replay decode payload for %s: %w", e.ID, err)
+		}
+
+		if _, err := s.Get(e.BookID); errors.Is(err, store.ErrNotFound) {
+			if _, err := s.Create(b); err != nil {
+				return fmt.Errorf("events: replay create %s: %w", e.BookID, err)
+			}
+			continue
+		}
+		if _, err := s.Update(e.BookID, b); err != nil {
+			return fmt.Errorf("events: replay update %s: %w", e.BookID, err)
+		}
+	}
+	return nil
+}
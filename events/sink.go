@@ -0,0 +1,73 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// StdoutSink prints every event as a line of JSON - the simplest possible
+// sink, mostly useful for local development.
+type StdoutSink struct{}
+
+func (StdoutSink) Emit(e BookEvent) (BookEvent, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return e, fmt.Errorf("events: marshal for stdout: %w", err)
+	}
+	_, err = fmt.Println(string(b))
+	return e, err
+}
+
+// FileSink appends every event as a line of JSON to a file, so it can
+// later be replayed with ReadLogFile + ReplayEvents.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSink returns a FileSink that appends to path, creating it if it
+// doesn't exist.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Emit(e BookEvent) (BookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return e, fmt.Errorf("events: open log file: %w", err)
+	}
+	defer f.Close()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return e, fmt.Errorf("events: marshal for file: %w", err)
+	}
+	_, err = f.Write(append(b, '\n'))
+	return e, err
+}
+
+// ChannelSink forwards events onto a channel - a stand-in for wiring up a
+// real message bus (Kafka, NATS, ...) without pulling in a client library
+// for this project. Sends are non-blocking: a full channel drops the
+// event rather than stalling the caller that triggered it.
+type ChannelSink struct {
+	ch chan<- BookEvent
+}
+
+// NewChannelSink returns a ChannelSink that forwards onto ch.
+func NewChannelSink(ch chan<- BookEvent) *ChannelSink {
+	return &ChannelSink{ch: ch}
+}
+
+func (s *ChannelSink) Emit(e BookEvent) (BookEvent, error) {
+	select {
+	case s.ch <- e:
+	default:
+	}
+	return e, nil
+}
@@ -0,0 +1,130 @@
+// Package librarian models checkout/return as a queue of work items handled
+// by a small pool of worker goroutines that drain one shared queue. With
+// more than one worker, two requests for the same book can be processed at
+// the same time; it is the Store's own synchronization that keeps their
+// quantity updates from racing.
+package librarian
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/abdullah-hamada7/Go-REST-API/store"
+)
+
+// RequestType distinguishes the two operations workers can perform.
+type RequestType int
+
+const (
+	Checkout RequestType = iota
+	Return
+)
+
+func (t RequestType) String() string {
+	switch t {
+	case Checkout:
+		return "checkout"
+	case Return:
+		return "return"
+	default:
+		return "unknown"
+	}
+}
+
+// Request is one unit of work submitted to the pool: check out or return
+// the book identified by ISBN. respond carries the outcome back to
+// whichever goroutine called Submit.
+type Request struct {
+	ISBN    string
+	Type    RequestType
+	respond chan Response
+}
+
+// Response is what a worker sends back after processing a Request.
+type Response struct {
+	Book store.Book
+	Err  error
+}
+
+// WorkerStatus is a snapshot of one worker's state, returned by Pool.Status
+// for the GET /librarians endpoint.
+type WorkerStatus struct {
+	ID   int  `json:"id" xml:"id,attr"`
+	Busy bool `json:"busy" xml:"busy"`
+}
+
+// Pool is a fixed-size set of worker goroutines that drain a shared request
+// queue. Handlers submit work via Submit instead of calling the Store
+// directly.
+type Pool struct {
+	store store.Store
+	queue chan Request
+	busy  []int32 // atomic bool per worker, indexed by worker id
+}
+
+// NewPool starts size workers pulling from a queue of the given depth and
+// returns the Pool handle used to submit work and inspect worker status.
+func NewPool(s store.Store, size, queueDepth int) *Pool {
+	p := &Pool{
+		store: s,
+		queue: make(chan Request, queueDepth),
+		busy:  make([]int32, size),
+	}
+	for id := 0; id < size; id++ {
+		go p.work(id)
+	}
+	return p
+}
+
+func (p *Pool) work(id int) {
+	for req := range p.queue {
+		atomic.StoreInt32(&p.busy[id], 1)
+		req.respond <- p.process(req)
+		atomic.StoreInt32(&p.busy[id], 0)
+	}
+}
+
+func (p *Pool) process(req Request) Response {
+	var (
+		b   store.Book
+		err error
+	)
+	switch req.Type {
+	case Checkout:
+		b, err = p.store.Checkout(req.ISBN)
+	case Return:
+		b, err = p.store.Return(req.ISBN)
+	default:
+		err = fmt.Errorf("librarian: unknown request type %v", req.Type)
+	}
+	return Response{Book: b, Err: err}
+}
+
+// Submit enqueues a request for isbn and blocks until a worker processes it
+// or ctx is done, whichever comes first.
+func (p *Pool) Submit(ctx context.Context, isbn string, typ RequestType) (store.Book, error) {
+	req := Request{ISBN: isbn, Type: typ, respond: make(chan Response, 1)}
+
+	select {
+	case p.queue <- req:
+	case <-ctx.Done():
+		return store.Book{}, ctx.Err()
+	}
+
+	select {
+	case resp := <-req.respond:
+		return resp.Book, resp.Err
+	case <-ctx.Done():
+		return store.Book{}, ctx.Err()
+	}
+}
+
+// Status reports every worker's current busy/idle state, for GET /librarians.
+func (p *Pool) Status() []WorkerStatus {
+	out := make([]WorkerStatus, len(p.busy))
+	for id := range p.busy {
+		out[id] = WorkerStatus{ID: id, Busy: atomic.LoadInt32(&p.busy[id]) == 1}
+	}
+	return out
+}
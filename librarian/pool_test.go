@@ -0,0 +1,96 @@
+package librarian
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abdullah-hamada7/Go-REST-API/store"
+)
+
+// TestPoolConcurrentCheckoutsNoOversubscription fires hundreds of
+// concurrent checkouts and returns against a single book and asserts the
+// pool never oversubscribes it: Quantity never goes negative, and every
+// checkout that reported success is matched by either a later return or a
+// unit still reflected in the book's final quantity.
+func TestPoolConcurrentCheckoutsNoOversubscription(t *testing.T) {
+	const initialQuantity = 50
+	const attempts = 300
+
+	s, err := store.New("memory", "")
+	if err != nil {
+		t.Fatalf("store.New: %v", err)
+	}
+	if _, err := s.Create(store.Book{ID: "concurrent", Title: "Concurrency 101", Quantity: initialQuantity}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	pool := NewPool(s, 8, 64)
+
+	var (
+		wg          sync.WaitGroup
+		mu          sync.Mutex
+		checkedOut  int
+		outOfStock  int
+		returned    int
+		sawNegative bool
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, err := pool.Submit(ctx, "concurrent", Checkout)
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				checkedOut++
+				if b.Quantity < 0 {
+					sawNegative = true
+				}
+			case store.ErrOutOfStock:
+				outOfStock++
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Return half of what was checked out, so the final tally exercises
+	// both Checkout and Return under the same concurrency.
+	toReturn := checkedOut / 2
+	wg.Add(toReturn)
+	for i := 0; i < toReturn; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := pool.Submit(ctx, "concurrent", Return); err == nil {
+				mu.Lock()
+				returned++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sawNegative {
+		t.Fatal("book quantity went negative under concurrent checkout")
+	}
+	if checkedOut+outOfStock != attempts {
+		t.Fatalf("checkedOut(%d) + outOfStock(%d) = %d, want %d", checkedOut, outOfStock, checkedOut+outOfStock, attempts)
+	}
+
+	final, err := s.Get("concurrent")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if final.Quantity < 0 {
+		t.Fatalf("final quantity went negative: %d", final.Quantity)
+	}
+	if want := initialQuantity - (checkedOut - returned); final.Quantity != want {
+		t.Fatalf("final quantity = %d, want %d (initial %d - outstanding %d)", final.Quantity, want, initialQuantity, checkedOut-returned)
+	}
+}
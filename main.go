@@ -3,273 +3,332 @@ package main
 
 // Import dependencies
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
 	"net/http" // Standard library for HTTP status codes
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin" // Web framework for building APIs
+
+	"github.com/abdullah-hamada7/Go-REST-API/events"
+	"github.com/abdullah-hamada7/Go-REST-API/librarian"
+	"github.com/abdullah-hamada7/Go-REST-API/openapi"
+	"github.com/abdullah-hamada7/Go-REST-API/response"
+	"github.com/abdullah-hamada7/Go-REST-API/store"
 )
 
+// checkoutTimeout bounds how long a checkout/return handler will wait on
+// the librarian pool before giving up and reporting a timeout.
+const checkoutTimeout = 2 * time.Second
+
 // =========================
 // Models (Data Structures)
 // =========================
 
-// Book represents the main data structure stored in our system
-type Book struct {
-	ID       string  `json:"id"`       // Unique identifier, exported as "id" in JSON
-	Title    string  `json:"title"`    // Book title, exported as "title" in JSON
-	Author   *string `json:"author"`   // Pointer to string - allows null values in JSON
-	Quantity int     `json:"quantity"` // Number of copies available
-}
+// Book is re-exported from the store package so handlers and callers of the
+// API don't need to import store directly just to reference the shape.
+type Book = store.Book
 
 // BookCreateInput defines the expected structure for creating new books
 // Includes validation rules using Gin's binding tags
 type BookCreateInput struct {
-	ID       string  `json:"id" binding:"required"`             // Must be provided
-	Title    string  `json:"title" binding:"required,min=3"`    // Required & at least 3 chars
-	Author   *string `json:"author" binding:"required"`         // Must be provided (can be null string)
-	Quantity int     `json:"quantity" binding:"required,gte=1"` // Required & >= 1
+	ID       string  `json:"id" xml:"id" binding:"required"`                   // Must be provided
+	Title    string  `json:"title" xml:"title" binding:"required,min=3"`       // Required & at least 3 chars
+	Author   *string `json:"author" xml:"author" binding:"required"`           // Must be provided (can be null string)
+	Quantity int     `json:"quantity" xml:"quantity" binding:"required,gte=1"` // Required & >= 1
+	Ratings  []int   `json:"ratings" xml:"ratings>rating"`                     // Optional
 }
 
 // BookPutInput for FULL updates (PUT requests) - replaces entire book
 type BookPutInput struct {
-	Title    string  `json:"title" binding:"required,min=3"` // All fields required for full replacement
-	Author   *string `json:"author" binding:"required"`
-	Quantity int     `json:"quantity" binding:"required,gte=1"`
+	Title    string  `json:"title" xml:"title" binding:"required,min=3"` // All fields required for full replacement
+	Author   *string `json:"author" xml:"author" binding:"required"`
+	Quantity int     `json:"quantity" xml:"quantity" binding:"required,gte=1"`
+	Ratings  []int   `json:"ratings" xml:"ratings>rating"`
 }
 
 // BookPatchInput for PARTIAL updates (PATCH requests) - updates only provided fields
 // All fields are pointers so we can detect which fields were actually provided
 type BookPatchInput struct {
-	Title    *string `json:"title"`    // Pointer - nil if not provided in request
-	Author   *string `json:"author"`   // Pointer - nil if not provided in request
-	Quantity *int    `json:"quantity"` // Pointer - nil if not provided in request
+	Title    *string `json:"title" xml:"title"`       // Pointer - nil if not provided in request
+	Author   *string `json:"author" xml:"author"`     // Pointer - nil if not provided in request
+	Quantity *int    `json:"quantity" xml:"quantity"` // Pointer - nil if not provided in request
+	Ratings  *[]int  `json:"ratings" xml:"ratings"`   // Pointer - nil if not provided in request
 }
 
 // =========================
-// Initial Data
+// Handlers
 // =========================
 
-// Helper function to create string pointers
-// Needed because we can't directly take the address of string literals like &"hello"
-func ptr(s string) *string {
-	return &s
+// Handlers holds the dependencies every route needs. Routes are registered
+// as methods on this struct (instead of free functions closing over a
+// package-level global) so a test can build a Handlers backed by a fake
+// store.Store.
+type Handlers struct {
+	store     store.Store
+	pool      *librarian.Pool
+	eventSink events.Sink
+	eventLog  *events.Log
 }
 
-// Initial book data stored in memory (in real app, this would be a database)
-var books = []Book{
-	{ID: "1", Title: "Book One", Author: ptr("Author One"), Quantity: 1},
-	{ID: "2", Title: "Book Two", Author: ptr("Author Two"), Quantity: 2},
-	{ID: "3", Title: "Book Three", Author: ptr("Author Three"), Quantity: 3},
+// NewHandlers wires up a Handlers value for the given Store and librarian
+// Pool. Checkout/return requests go through pool; every other route still
+// talks to store directly. Every mutation is also recorded through sink,
+// and eventLog backs the /books/:id/events and /events read endpoints.
+func NewHandlers(s store.Store, pool *librarian.Pool, sink events.Sink, eventLog *events.Log) *Handlers {
+	return &Handlers{store: s, pool: pool, eventSink: sink, eventLog: eventLog}
 }
 
-// =========================
-// Handler Functions
-// =========================
+// recordEvent snapshots book into a BookEvent of the given type and emits
+// it through h.eventSink. Emit errors are logged rather than surfaced to
+// the caller - a broken audit sink shouldn't fail the book mutation that
+// already succeeded.
+func (h *Handlers) recordEvent(bookID string, t events.Type, book store.Book) {
+	payload, err := json.Marshal(book)
+	if err != nil {
+		log.Printf("events: marshal payload for %s: %v", bookID, err)
+		return
+	}
+	if _, err := h.eventSink.Emit(events.BookEvent{
+		BookID:    bookID,
+		EventType: t,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}); err != nil {
+		log.Printf("events: emit %s for %s: %v", t, bookID, err)
+	}
+}
 
 // getBooks returns all books in the system
-func getBooks(c *gin.Context) {
-	// c.JSON sends a JSON response with HTTP 200 status code
-	// gin.Context contains request info and response methods
-	c.JSON(http.StatusOK, books)
+func (h *Handlers) getBooks(c *gin.Context) {
+	books, err := h.store.GetAll()
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
+	}
+	response.Default.List(c, books)
 }
 
 // getBook returns a specific book by ID
-func getBook(c *gin.Context) {
-	// Extract "id" parameter from URL path (e.g., /books/1 -> id = "1")
+func (h *Handlers) getBook(c *gin.Context) {
 	id := c.Param("id")
 
-	// Loop through all books to find matching ID
-	// range returns (index, value) - we ignore index with _
-	for _, b := range books {
-		if b.ID == id {
-			// Found book - return it with 200 OK
-			c.JSON(http.StatusOK, b)
-			return // Exit function early
-		}
+	b, err := h.store.Get(id)
+	if errors.Is(err, store.ErrNotFound) {
+		response.Default.BookNotFound(c, id)
+		return
 	}
-
-	// If we get here, no book was found - return 404 Not Found
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
+	}
+	response.Default.OK(c, "book found", b)
 }
 
 // createBook adds a new book to the system
-func createBook(c *gin.Context) {
-	var input BookCreateInput // Declare variable to hold parsed JSON data
-
-	// This is the Go error handling pattern explained earlier:
-	// 1. c.ShouldBindJSON(&input) parses request JSON into input struct
-	// 2. It returns an error if JSON is invalid or validation fails
-	// 3. if err := ...; err != nil checks if error occurred
-	// 4. If error, return 400 Bad Request with error message
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return // Exit early on error
+func (h *Handlers) createBook(c *gin.Context) {
+	var input BookCreateInput // Declare variable to hold parsed JSON or XML data
+
+	if err := bindBody(c, &input); err != nil {
+		response.Default.InvalidMessage(c, err)
+		return
 	}
 
-	// Create new book from validated input data
 	newBook := Book{
 		ID:       input.ID,
 		Title:    input.Title,
 		Author:   input.Author,
 		Quantity: input.Quantity,
+		Ratings:  input.Ratings,
 	}
 
-	// Add new book to our slice
-	books = append(books, newBook)
+	created, err := h.store.Create(newBook)
+	if errors.Is(err, store.ErrExists) {
+		response.Default.Conflict(c, "book already exists", newBook)
+		return
+	}
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
+	}
 
-	// Return 201 Created status with the new book data
-	c.JSON(http.StatusCreated, newBook)
+	h.recordEvent(created.ID, events.EventCreated, created)
+	response.Default.InsertSuccess(c, created)
 }
 
 // replaceBook completely replaces an existing book (PUT)
-func replaceBook(c *gin.Context) {
+func (h *Handlers) replaceBook(c *gin.Context) {
 	id := c.Param("id")    // Get book ID from URL
 	var input BookPutInput // Struct for full replacement data
 
-	// Parse and validate input JSON
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindBody(c, &input); err != nil {
+		response.Default.InvalidMessage(c, err)
 		return
 	}
 
-	// Find book by ID and update all fields
-	for i, b := range books {
-		if b.ID == id {
-			// Update the book in the slice (using index i)
-			books[i].Title = input.Title
-			books[i].Author = input.Author
-			books[i].Quantity = input.Quantity
-
-			// Return updated book
-			c.JSON(http.StatusOK, books[i])
-			return
-		}
+	updated, err := h.store.Update(id, Book{
+		Title:    input.Title,
+		Author:   input.Author,
+		Quantity: input.Quantity,
+		Ratings:  input.Ratings,
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		response.Default.BookNotFound(c, id)
+		return
+	}
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
 	}
 
-	// Book not found
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+	h.recordEvent(id, events.EventUpdated, updated)
+	response.Default.OK(c, "book replaced", updated)
 }
 
 // updateBook partially updates a book (PATCH)
-func updateBook(c *gin.Context) {
+func (h *Handlers) updateBook(c *gin.Context) {
 	id := c.Param("id")
 	var input BookPatchInput // All fields are pointers
 
-	// Parse input - only provided fields will be non-nil
-	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindBody(c, &input); err != nil {
+		response.Default.InvalidMessage(c, err)
 		return
 	}
 
-	// Find and update only the provided fields
-	for i, b := range books {
-		if b.ID == id {
-			// Only update Title if provided (pointer not nil)
-			if input.Title != nil {
-				books[i].Title = *input.Title // Dereference pointer to get actual value
-			}
-			// Only update Author if provided
-			if input.Author != nil {
-				books[i].Author = input.Author
-			}
-			// Only update Quantity if provided
-			if input.Quantity != nil {
-				books[i].Quantity = *input.Quantity // Dereference pointer
-			}
-
-			c.JSON(http.StatusOK, books[i])
-			return
-		}
+	updated, err := h.store.Patch(id, store.BookPatch{
+		Title:    input.Title,
+		Author:   input.Author,
+		Quantity: input.Quantity,
+		Ratings:  input.Ratings,
+	})
+	if errors.Is(err, store.ErrNotFound) {
+		response.Default.BookNotFound(c, id)
+		return
+	}
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
 	}
 
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+	h.recordEvent(id, events.EventUpdated, updated)
+	response.Default.OK(c, "book updated", updated)
 }
 
 // deleteBook removes a book from the system
-func deleteBook(c *gin.Context) {
+func (h *Handlers) deleteBook(c *gin.Context) {
 	id := c.Param("id")
 
-	// Find book by ID
-	for i, b := range books {
-		if b.ID == id {
-			// Remove book from slice using slice manipulation:
-			// books[:i] = elements from start to index i-1
-			// books[i+1:] = elements from index i+1 to end
-			// append(...) combines them, effectively removing element at index i
-			// ... unpacks the second slice into individual elements
-			books = append(books[:i], books[i+1:]...)
-
-			// Return 204 No Content (successful deletion, no response body)
-			c.Status(http.StatusNoContent)
-			return
-		}
+	// Fetched before deleting so the event payload can carry a snapshot of
+	// the book as it existed right before removal.
+	b, err := h.store.Get(id)
+	if errors.Is(err, store.ErrNotFound) {
+		response.Default.BookNotFound(c, id)
+		return
+	}
+	if err != nil {
+		response.Default.Internal(c, err)
+		return
 	}
 
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+	if err := h.store.Delete(id); err != nil {
+		response.Default.Internal(c, err)
+		return
+	}
+
+	h.recordEvent(id, events.EventDeleted, b)
+	response.Default.Deleted(c)
 }
 
-// checkoutBook handles checking out a book (decreasing quantity by 1)
+// checkoutBook handles checking out a book (decreasing quantity by 1).
+// Instead of calling the Store directly, it enqueues a Request with the
+// librarian pool and blocks on the response channel. Workers run
+// concurrently; the Store's own locking keeps concurrent checkouts of the
+// same book from oversubscribing it.
 // Now uses the route /books/checkout/:id instead of /books/:id/checkout
-func checkoutBook(c *gin.Context) {
+func (h *Handlers) checkoutBook(c *gin.Context) {
 	id := c.Param("id") // Extract "id" parameter from URL path (e.g., /books/checkout/1 -> id = "1")
 
-	// Loop through all books to find the one with matching ID
-	// We need both index (i) and value (b) because:
-	// - b (value) is used for reading/comparison (checking ID and current Quantity)
-	// - i (index) is used for modification (updating Quantity in the original slice)
-	for i, b := range books {
-		if b.ID == id {
-			if b.Quantity > 0 {
-				// Book found AND has available copies
-				// Decrement the quantity by 1 (check out one copy)
-				// Must use books[i].Quantity-- NOT b.Quantity-- because:
-				// - b is a COPY of the book from the range loop
-				// - books[i] accesses the ORIGINAL book in the slice
-				books[i].Quantity--
-
-				// Return 200 OK status (successful checkout)
-				c.JSON(http.StatusOK, gin.H{
-					"message": "Book checked out successfully",
-					"book":    books[i],
-				})
-				return // Exit function early
-			}
-			// Book found but no copies available (quantity is 0)
-			// Use 409 Conflict to indicate the request conflicts with current state
-			c.JSON(http.StatusConflict, gin.H{
-				"message": "Book is out of stock",
-				"book":    b,
-			})
-			return
-		}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkoutTimeout)
+	defer cancel()
+
+	b, err := h.pool.Submit(ctx, id, librarian.Checkout)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		response.Default.BookNotFound(c, id)
+	case errors.Is(err, store.ErrOutOfStock):
+		response.Default.OutOfStock(c, b)
+	case errors.Is(err, context.DeadlineExceeded):
+		response.Default.Timeout(c, "timed out waiting for a librarian")
+	case err != nil:
+		response.Default.Internal(c, err)
+	default:
+		h.recordEvent(id, events.EventCheckedOut, b)
+		response.Default.OK(c, "book checked out successfully", b)
 	}
-
-	// If we get here, no book was found with the given ID
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
 }
 
-// returnBook handles returning a book (increasing quantity by 1)
+// returnBook handles returning a book (increasing quantity by 1), routed
+// through the librarian pool for the same reason as checkoutBook.
 // Now uses the route /books/return/:id instead of /books/:id/return
-func returnBook(c *gin.Context) {
+func (h *Handlers) returnBook(c *gin.Context) {
 	id := c.Param("id") // Extract "id" parameter from URL path (e.g., /books/return/1 -> id = "1")
 
-	// Loop through all books to find the one with matching ID
-	for i, b := range books {
-		if b.ID == id {
-			// Book found - increment the quantity by 1 (return one copy)
-			// Using books[i].Quantity++ to modify the original book in the slice
-			books[i].Quantity++
-
-			// Return 200 OK status with success message and updated book
-			c.JSON(http.StatusOK, gin.H{
-				"message": "Book returned successfully",
-				"book":    books[i],
-			})
-			return // Exit function early
+	ctx, cancel := context.WithTimeout(c.Request.Context(), checkoutTimeout)
+	defer cancel()
+
+	b, err := h.pool.Submit(ctx, id, librarian.Return)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		response.Default.BookNotFound(c, id)
+	case errors.Is(err, context.DeadlineExceeded):
+		response.Default.Timeout(c, "timed out waiting for a librarian")
+	case err != nil:
+		response.Default.Internal(c, err)
+	default:
+		h.recordEvent(id, events.EventReturned, b)
+		response.Default.OK(c, "book returned successfully", b)
+	}
+}
+
+// listLibrarians reports each worker's busy/idle state.
+func (h *Handlers) listLibrarians(c *gin.Context) {
+	response.Default.Workers(c, h.pool.Status())
+}
+
+// getBookEvents returns the full event history for one book, oldest first.
+func (h *Handlers) getBookEvents(c *gin.Context) {
+	response.Default.Events(c, h.eventLog.ForBook(c.Param("id")))
+}
+
+// listEvents returns events across all books, filtered and paginated by
+// query params: since (RFC3339 timestamp), type, offset, limit (default 50).
+func (h *Handlers) listEvents(c *gin.Context) {
+	q := events.Query{Type: events.Type(c.Query("type")), Limit: 50}
+
+	if v := c.Query("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			response.Default.InvalidMessage(c, err)
+			return
+		}
+		q.Since = since
+	}
+	if v := c.Query("offset"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			q.Offset = n
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			q.Limit = n
 		}
 	}
 
-	// If we get here, no book was found with the given ID
-	c.JSON(http.StatusNotFound, gin.H{"message": "Book not found"})
+	response.Default.Events(c, h.eventLog.Query(q))
 }
 
 // =========================
@@ -277,18 +336,115 @@ func returnBook(c *gin.Context) {
 // =========================
 
 func main() {
+	providerName := os.Getenv("STORE_PROVIDER")
+	if providerName == "" {
+		providerName = "memory"
+	}
+
+	// EVENT_LOG_FILE, if set, makes the audit log durable across restarts:
+	// existing events are replayed into the store before it starts serving
+	// requests, and every new event is appended back to the same file.
+	eventLogFile := os.Getenv("EVENT_LOG_FILE")
+
+	var (
+		s   store.Store
+		err error
+	)
+	switch {
+	case providerName == "memory" && eventLogFile != "":
+		// Skip the memory backend's usual demo seed data - replay below is
+		// about to populate the store from the log, and the seed books
+		// would otherwise reappear as phantom inventory with no
+		// corresponding audit event.
+		s = store.NewMemoryWithoutSeed()
+	default:
+		s, err = store.New(providerName, os.Getenv("STORE_DSN"))
+		if err != nil {
+			log.Fatalf("store: %v", err)
+		}
+	}
+
+	workers := 4
+	if v := os.Getenv("LIBRARIAN_WORKERS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			workers = n
+		}
+	}
+	pool := librarian.NewPool(s, workers, 64)
+
+	eventLog := events.NewLog()
+	sink := events.MultiSink{eventLog, events.StdoutSink{}}
+	if eventLogFile != "" {
+		eventLog, err = events.ReadLogFile(eventLogFile)
+		if err != nil {
+			log.Fatalf("events: %v", err)
+		}
+		if err := events.ReplayEvents(s, eventLog); err != nil {
+			log.Fatalf("events: %v", err)
+		}
+		sink = events.MultiSink{eventLog, events.StdoutSink{}, events.NewFileSink(eventLogFile)}
+	}
+
+	h := NewHandlers(s, pool, sink, eventLog)
+
 	// Create Gin router with default middleware (logging, panic recovery)
 	router := gin.Default()
-
-	// Register routes - map HTTP methods and paths to handler functions
-	router.GET("/books", getBooks)                   // Get all books
-	router.GET("/books/:id", getBook)                // Get single book by ID
-	router.POST("/books", createBook)                // Create new book
-	router.PUT("/books/:id", replaceBook)            // Fully replace book
-	router.PATCH("/books/:id", updateBook)           // Partially update book
-	router.DELETE("/books/:id", deleteBook)          // Delete book
-	router.POST("/books/checkout/:id", checkoutBook) // Check out a book (decrease quantity) - ROUTE CHANGED
-	router.POST("/books/return/:id", returnBook)     // Return a book (increase quantity) - ROUTE CHANGED
+	router.Use(requestIDMiddleware())
+
+	// api records method, path, request/response types and status codes for
+	// every route as it registers it, so /openapi.json is generated from
+	// the same declarations that wire up the handlers - no separate spec to
+	// keep in sync by hand.
+	api := openapi.NewBuilder("Go REST API", "1.0.0")
+
+	api.Register(router, openapi.Route{
+		Method: http.MethodGet, Path: "/books",
+		Summary: "List all books", Response: response.Envelope{}, StatusCodes: []int{http.StatusOK},
+	}, h.getBooks)
+	api.Register(router, openapi.Route{
+		Method: http.MethodGet, Path: "/books/:id",
+		Summary: "Get a book by ID", Response: response.Envelope{}, StatusCodes: []int{http.StatusOK, http.StatusNotFound},
+	}, h.getBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodPost, Path: "/books",
+		Summary: "Create a book", Request: BookCreateInput{}, Response: response.Envelope{}, StatusCodes: []int{http.StatusCreated, http.StatusBadRequest, http.StatusConflict},
+	}, h.createBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodPut, Path: "/books/:id",
+		Summary: "Replace a book", Request: BookPutInput{}, Response: response.Envelope{}, StatusCodes: []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound},
+	}, h.replaceBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodPatch, Path: "/books/:id",
+		Summary: "Partially update a book", Request: BookPatchInput{}, Response: response.Envelope{}, StatusCodes: []int{http.StatusOK, http.StatusBadRequest, http.StatusNotFound},
+	}, h.updateBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodDelete, Path: "/books/:id",
+		Summary: "Delete a book", StatusCodes: []int{http.StatusNoContent, http.StatusNotFound},
+	}, h.deleteBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodPost, Path: "/books/checkout/:id",
+		Summary: "Check out a book", Response: response.Envelope{}, StatusCodes: []int{http.StatusOK, http.StatusConflict, http.StatusNotFound},
+	}, h.checkoutBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodPost, Path: "/books/return/:id",
+		Summary: "Return a book", Response: response.Envelope{}, StatusCodes: []int{http.StatusOK, http.StatusNotFound},
+	}, h.returnBook)
+	api.Register(router, openapi.Route{
+		Method: http.MethodGet, Path: "/librarians",
+		Summary: "Report librarian worker pool status", StatusCodes: []int{http.StatusOK},
+	}, h.listLibrarians)
+	api.Register(router, openapi.Route{
+		Method: http.MethodGet, Path: "/books/:id/events",
+		Summary: "List the event history for one book", StatusCodes: []int{http.StatusOK},
+	}, h.getBookEvents)
+	api.Register(router, openapi.Route{
+		Method: http.MethodGet, Path: "/events",
+		Summary: "List events across all books, filtered and paginated", StatusCodes: []int{http.StatusOK},
+	}, h.listEvents)
+
+	// Generated spec + Swagger UI, kept in sync with the routes above.
+	router.GET("/openapi.json", api.SpecHandler())
+	router.GET("/docs", openapi.SwaggerUIHandler("/openapi.json"))
 
 	// Start HTTP server on port 8080
 	// This blocks and keeps the server running until terminated
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindBody decodes the request body into dst, honoring Content-Type: a
+// request sent as application/xml is parsed as XML, anything else
+// (including the usual application/json) falls back to JSON binding.
+func bindBody(c *gin.Context, dst interface{}) error {
+	if strings.Contains(strings.ToLower(c.GetHeader("Content-Type")), "xml") {
+		return c.ShouldBindXML(dst)
+	}
+	return c.ShouldBindJSON(dst)
+}
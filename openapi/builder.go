@@ -0,0 +1,37 @@
+package openapi
+
+import "github.com/gin-gonic/gin"
+
+// Route captures everything about one registered endpoint that the spec
+// needs: its method, path, request/response shapes, and the status codes
+// it can produce. Request/Response may be nil for handlers with no body.
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Request     interface{}
+	Response    interface{}
+	StatusCodes []int
+}
+
+// Builder registers gin routes and records their shape at the same time,
+// so the generated spec can never drift from main's route table - there is
+// only one place routes get declared.
+type Builder struct {
+	title   string
+	version string
+	routes  []Route
+}
+
+// NewBuilder starts a Builder for an API with the given title and version,
+// both of which land in the spec's info object.
+func NewBuilder(title, version string) *Builder {
+	return &Builder{title: title, version: version}
+}
+
+// Register wires handlers onto router at route.Method/route.Path and
+// records route for the spec.
+func (b *Builder) Register(router gin.IRoutes, route Route, handlers ...gin.HandlerFunc) {
+	router.Handle(route.Method, route.Path, handlers...)
+	b.routes = append(b.routes, route)
+}
@@ -0,0 +1,226 @@
+// Package openapi reflects on the API's request/response structs to
+// generate an OpenAPI 3.1 document and serves a Swagger UI for it, so the
+// spec can never drift out of sync with the handlers it describes.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is a minimal JSON Schema (OpenAPI 3.1 reuses JSON Schema) - just
+// enough to describe the flat structs this API binds request bodies to.
+type Schema struct {
+	Type       string
+	Format     string
+	Properties []NamedSchema
+	Required   []string
+	Items      *Schema
+	Minimum    *float64
+	MinLength  *int
+	Nullable   bool
+}
+
+// NamedSchema pairs a property name with its Schema. Properties are kept as
+// a slice (not a map) so MarshalJSON can emit them in a fixed order instead
+// of Go's alphabetical map-key ordering.
+type NamedSchema struct {
+	Name   string
+	Schema Schema
+}
+
+// MarshalJSON renders Schema by hand so object keys - and critically,
+// "properties" entries - come out in a deterministic order rather than the
+// order encoding/json would choose for a map.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	write := func(key string, val interface{}) error {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		kb, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		vb, err := json.Marshal(val)
+		if err != nil {
+			return err
+		}
+		buf.Write(kb)
+		buf.WriteByte(':')
+		buf.Write(vb)
+		return nil
+	}
+
+	if s.Type != "" {
+		if err := write("type", s.Type); err != nil {
+			return nil, err
+		}
+	}
+	if s.Format != "" {
+		if err := write("format", s.Format); err != nil {
+			return nil, err
+		}
+	}
+	if s.Nullable {
+		if err := write("nullable", true); err != nil {
+			return nil, err
+		}
+	}
+	if s.Minimum != nil {
+		if err := write("minimum", *s.Minimum); err != nil {
+			return nil, err
+		}
+	}
+	if s.MinLength != nil {
+		if err := write("minLength", *s.MinLength); err != nil {
+			return nil, err
+		}
+	}
+	if s.Items != nil {
+		if err := write("items", s.Items); err != nil {
+			return nil, err
+		}
+	}
+	if len(s.Properties) > 0 {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.WriteString(`"properties":{`)
+		for i, p := range s.Properties {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			kb, err := json.Marshal(p.Name)
+			if err != nil {
+				return nil, err
+			}
+			vb, err := json.Marshal(p.Schema)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(kb)
+			buf.WriteByte(':')
+			buf.Write(vb)
+		}
+		buf.WriteByte('}')
+	}
+	if len(s.Required) > 0 {
+		if err := write("required", s.Required); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Reflect builds a Schema for v's type. Structs are walked field-by-field,
+// honoring json tags for naming and binding tags (required, min, gte) for
+// validation constraints; pointers become nullable; slices become arrays.
+func Reflect(v interface{}) Schema {
+	return reflectType(reflect.TypeOf(v))
+}
+
+func reflectType(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return reflectStruct(t)
+	case reflect.Slice, reflect.Array:
+		items := reflectType(t.Elem())
+		return Schema{Type: "array", Items: &items}
+	case reflect.String:
+		return Schema{Type: "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{Type: "number"}
+	case reflect.Bool:
+		return Schema{Type: "boolean"}
+	default:
+		return Schema{Type: "object"}
+	}
+}
+
+// fieldEntry tracks a struct field's resolved JSON name alongside its
+// declaration index, so fields can be sorted by declared order first and
+// name only as a tiebreaker.
+type fieldEntry struct {
+	name string
+	idx  int
+}
+
+func reflectStruct(t reflect.Type) Schema {
+	var entries []fieldEntry
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" { // unexported field
+			continue
+		}
+		name := f.Name
+		if tag := f.Tag.Get("json"); tag != "" {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+		}
+		entries = append(entries, fieldEntry{name: name, idx: i})
+	}
+
+	// Declared struct field order first; alphabetical only breaks ties
+	// (which in practice means never, for a flat struct - but keeps the
+	// ordering well-defined if this is ever extended to embedded fields).
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].idx != entries[j].idx {
+			return entries[i].idx < entries[j].idx
+		}
+		return entries[i].name < entries[j].name
+	})
+
+	var props []NamedSchema
+	var required []string
+	for _, e := range entries {
+		f := t.Field(e.idx)
+		ft := f.Type
+		nullable := false
+		for ft.Kind() == reflect.Ptr {
+			nullable = true
+			ft = ft.Elem()
+		}
+		fieldSchema := reflectType(ft)
+		fieldSchema.Nullable = nullable
+
+		for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+			switch {
+			case rule == "required":
+				required = append(required, e.name)
+			case strings.HasPrefix(rule, "min="):
+				if n, err := strconv.Atoi(strings.TrimPrefix(rule, "min=")); err == nil {
+					fieldSchema.MinLength = &n
+				}
+			case strings.HasPrefix(rule, "gte="):
+				if n, err := strconv.ParseFloat(strings.TrimPrefix(rule, "gte="), 64); err == nil {
+					fieldSchema.Minimum = &n
+				}
+			}
+		}
+		props = append(props, NamedSchema{Name: e.name, Schema: fieldSchema})
+	}
+
+	sort.Strings(required)
+	return Schema{Type: "object", Properties: props, Required: required}
+}
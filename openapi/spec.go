@@ -0,0 +1,140 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Document is the subset of the OpenAPI 3.1 object this package produces.
+type Document struct {
+	OpenAPI string                          `json:"openapi"`
+	Info    Info                            `json:"info"`
+	Paths   map[string]map[string]Operation `json:"paths"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Operation struct {
+	Summary     string              `json:"summary,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes one templated path segment, e.g. {id} in
+// /books/{id}.
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Content map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Spec assembles the OpenAPI document from every route registered via
+// Register so far.
+func (b *Builder) Spec() Document {
+	doc := Document{
+		OpenAPI: "3.1.0",
+		Info:    Info{Title: b.title, Version: b.version},
+		Paths:   map[string]map[string]Operation{},
+	}
+
+	for _, route := range b.routes {
+		path, params := templatePath(route.Path)
+		op := Operation{
+			Summary:    route.Summary,
+			Parameters: params,
+			Responses:  map[string]Response{},
+		}
+
+		if route.Request != nil {
+			op.RequestBody = &RequestBody{
+				Content: map[string]MediaType{
+					"application/json": {Schema: Reflect(route.Request)},
+				},
+			}
+		}
+
+		codes := route.StatusCodes
+		if len(codes) == 0 {
+			codes = []int{http.StatusOK}
+		}
+		for _, code := range codes {
+			resp := Response{Description: http.StatusText(code)}
+			if route.Response != nil && code < 300 {
+				resp.Content = map[string]MediaType{
+					"application/json": {Schema: Reflect(route.Response)},
+				}
+			}
+			op.Responses[fmt.Sprintf("%d", code)] = resp
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][methodKey(route.Method)] = op
+	}
+
+	return doc
+}
+
+// templatePath converts gin's colon path params (/books/:id) to OpenAPI's
+// brace template syntax (/books/{id}), and returns a path Parameter for
+// each one so tooling (Swagger UI's "Try it out", codegen) knows they
+// exist.
+func templatePath(path string) (string, []Parameter) {
+	segments := strings.Split(path, "/")
+	var params []Parameter
+	for i, seg := range segments {
+		if !strings.HasPrefix(seg, ":") {
+			continue
+		}
+		name := strings.TrimPrefix(seg, ":")
+		segments[i] = "{" + name + "}"
+		params = append(params, Parameter{
+			Name: name, In: "path", Required: true, Schema: Schema{Type: "string"},
+		})
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// methodKey lowercases the HTTP method, since OpenAPI path item keys are
+// lowercase ("get", "post", ...) while gin/net-http constants are upper.
+func methodKey(method string) string {
+	out := make([]byte, len(method))
+	for i := 0; i < len(method); i++ {
+		c := method[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		out[i] = c
+	}
+	return string(out)
+}
+
+// SpecHandler serves the generated document as JSON, meant to be mounted at
+// GET /openapi.json.
+func (b *Builder) SpecHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, b.Spec())
+	}
+}
@@ -0,0 +1,36 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUITemplate renders Swagger UI against a given spec URL via the
+// public CDN build - no bundled assets to keep in the repo.
+const swaggerUITemplate = `<!DOCTYPE html>
+<html>
+<head>
+  <title>API docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>`
+
+// SwaggerUIHandler serves a Swagger UI page that loads its spec from
+// specURL (typically "/openapi.json"). Meant to be mounted at GET /docs.
+func SwaggerUIHandler(specURL string) gin.HandlerFunc {
+	page := fmt.Sprintf(swaggerUITemplate, specURL)
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	}
+}
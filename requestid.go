@@ -0,0 +1,39 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestIDHeader is both read (to honor a caller-supplied ID) and written
+// (so the caller can correlate it with logs/envelopes) on every request.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the gin.Context key response.Envelope reads its
+// RequestID from.
+const requestIDKey = "request_id"
+
+// requestIDMiddleware assigns every request an ID - reusing one the client
+// already sent via X-Request-ID, or minting a new one - so error and
+// success envelopes can report which request they belong to.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,307 @@
+// Package response centralizes how the book endpoints write their HTTP
+// responses, success or failure, as one consistent envelope instead of the
+// ad-hoc gin.H maps the handlers used to build by hand.
+package response
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/abdullah-hamada7/Go-REST-API/events"
+	"github.com/abdullah-hamada7/Go-REST-API/librarian"
+	"github.com/abdullah-hamada7/Go-REST-API/store"
+)
+
+// Code is a stable, machine-readable identifier for an envelope - clients
+// should branch on this, never on Message, which is free to change.
+type Code string
+
+const (
+	CodeOK               Code = "OK"
+	CodeCreated          Code = "CREATED"
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeInvalidID        Code = "INVALID_ID"
+	CodeBookNotFound     Code = "BOOK_NOT_FOUND"
+	CodeOutOfStock       Code = "OUT_OF_STOCK"
+	CodeConflict         Code = "CONFLICT"
+	CodeTimeout          Code = "TIMEOUT"
+	CodeInternal         Code = "INTERNAL_ERROR"
+)
+
+// FieldError describes one field that failed validation, surfaced in an
+// Envelope's Details when the triggering error is a validator.ValidationErrors.
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Message string `json:"message" xml:"message"`
+}
+
+// Envelope is the shape every book endpoint responds with, success or
+// failure, so a client only ever has to parse one shape.
+type Envelope struct {
+	XMLName   xml.Name                 `json:"-" xml:"response"`
+	Code      Code                     `json:"code" xml:"code"`
+	Message   string                   `json:"message" xml:"message"`
+	Details   interface{}              `json:"details,omitempty" xml:"details,omitempty"`
+	RequestID string                   `json:"request_id" xml:"request_id"`
+	Book      *store.Book              `json:"book,omitempty" xml:"book,omitempty"`
+	Books     []store.Book             `json:"books,omitempty" xml:"books>book,omitempty"`
+	Events    []events.BookEvent       `json:"events,omitempty" xml:"events>event,omitempty"`
+	Workers   []librarian.WorkerStatus `json:"workers,omitempty" xml:"workers>worker,omitempty"`
+}
+
+// String renders an Envelope as plain text, used for the API's text/plain
+// representation.
+func (e Envelope) String() string {
+	if e.Book != nil {
+		return fmt.Sprintf("%s: %s (%s)", e.Message, e.Book.String(), e.Code)
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// MarshalXML renders Envelope by hand instead of relying on the struct
+// tags above for Books/Events: encoding/xml doesn't honor omitempty on a
+// multi-segment tag like "books>book,omitempty", so an envelope with no
+// books (or no events) would otherwise always carry a stray empty
+// <books></books>/<events></events> alongside whatever the response
+// actually returned. Decoding still uses the struct tags via the default
+// UnmarshalXML.
+func (e Envelope) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "response"}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := enc.EncodeElement(e.Code, xml.StartElement{Name: xml.Name{Local: "code"}}); err != nil {
+		return err
+	}
+	if err := enc.EncodeElement(e.Message, xml.StartElement{Name: xml.Name{Local: "message"}}); err != nil {
+		return err
+	}
+	if e.Details != nil {
+		if err := enc.EncodeElement(e.Details, xml.StartElement{Name: xml.Name{Local: "details"}}); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeElement(e.RequestID, xml.StartElement{Name: xml.Name{Local: "request_id"}}); err != nil {
+		return err
+	}
+	if e.Book != nil {
+		if err := enc.EncodeElement(e.Book, xml.StartElement{Name: xml.Name{Local: "book"}}); err != nil {
+			return err
+		}
+	}
+	if len(e.Books) > 0 {
+		wrap := xml.StartElement{Name: xml.Name{Local: "books"}}
+		if err := enc.EncodeToken(wrap); err != nil {
+			return err
+		}
+		for _, b := range e.Books {
+			if err := enc.EncodeElement(b, xml.StartElement{Name: xml.Name{Local: "book"}}); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(wrap.End()); err != nil {
+			return err
+		}
+	}
+	if len(e.Events) > 0 {
+		wrap := xml.StartElement{Name: xml.Name{Local: "events"}}
+		if err := enc.EncodeToken(wrap); err != nil {
+			return err
+		}
+		for _, ev := range e.Events {
+			if err := enc.EncodeElement(ev, xml.StartElement{Name: xml.Name{Local: "event"}}); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(wrap.End()); err != nil {
+			return err
+		}
+	}
+	if len(e.Workers) > 0 {
+		wrap := xml.StartElement{Name: xml.Name{Local: "workers"}}
+		if err := enc.EncodeToken(wrap); err != nil {
+			return err
+		}
+		for _, w := range e.Workers {
+			if err := enc.EncodeElement(w, xml.StartElement{Name: xml.Name{Local: "worker"}}); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(wrap.End()); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// BookControllerResponse writes Envelopes for the book handlers. It's
+// stateless - every method just needs the request's *gin.Context - so the
+// zero value (see Default) is all callers ever need.
+type BookControllerResponse struct{}
+
+// Default is the BookControllerResponse every handler uses; it carries no
+// state, so there's never a reason to construct a second one.
+var Default = BookControllerResponse{}
+
+func requestID(c *gin.Context) string {
+	if v, ok := c.Get("request_id"); ok {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// write renders env in whichever representation the client asked for -
+// application/xml, text/plain, or application/json (the default) - mirroring
+// the negotiation every other book response already honors.
+func write(c *gin.Context, status int, env Envelope) {
+	switch negotiateFormat(c) {
+	case formatXML:
+		c.XML(status, env)
+	case formatText:
+		c.String(status, "%s", env.String())
+	default:
+		c.JSON(status, env)
+	}
+}
+
+type format int
+
+const (
+	formatJSON format = iota
+	formatXML
+	formatText
+)
+
+func negotiateFormat(c *gin.Context) format {
+	if f, ok := formatFromString(c.Query("format")); ok {
+		return f
+	}
+	if f, ok := formatFromString(c.GetHeader("Accept")); ok {
+		return f
+	}
+	return formatJSON
+}
+
+func formatFromString(s string) (format, bool) {
+	s = strings.ToLower(s)
+	switch {
+	case strings.Contains(s, "xml"):
+		return formatXML, true
+	case strings.Contains(s, "text/plain"), strings.Contains(s, "text"):
+		return formatText, true
+	case strings.Contains(s, "json"):
+		return formatJSON, true
+	default:
+		return formatJSON, false
+	}
+}
+
+// InvalidMessage reports a malformed or failed-validation request body. If
+// err is a validator.ValidationErrors, it's unwrapped into per-field
+// Details instead of a single opaque message.
+func (BookControllerResponse) InvalidMessage(c *gin.Context, err error) {
+	env := Envelope{Code: CodeValidationFailed, Message: err.Error(), RequestID: requestID(c)}
+	if verrs, ok := err.(validator.ValidationErrors); ok {
+		env.Message = "request validation failed"
+		details := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			details = append(details, FieldError{Field: fe.Field(), Message: fe.Tag()})
+		}
+		env.Details = details
+	}
+	write(c, http.StatusBadRequest, env)
+}
+
+// InvalidID reports a malformed identifier (path parameter or otherwise).
+func (BookControllerResponse) InvalidID(c *gin.Context, err error) {
+	write(c, http.StatusBadRequest, Envelope{
+		Code: CodeInvalidID, Message: err.Error(), RequestID: requestID(c),
+	})
+}
+
+// BookNotFound reports that no book exists with the given id.
+func (BookControllerResponse) BookNotFound(c *gin.Context, id string) {
+	write(c, http.StatusNotFound, Envelope{
+		Code: CodeBookNotFound, Message: fmt.Sprintf("book %q not found", id), RequestID: requestID(c),
+	})
+}
+
+// OutOfStock reports that book has no copies left to check out.
+func (BookControllerResponse) OutOfStock(c *gin.Context, book store.Book) {
+	write(c, http.StatusConflict, Envelope{
+		Code: CodeOutOfStock, Message: "book is out of stock", RequestID: requestID(c), Book: &book,
+	})
+}
+
+// Conflict reports any other request/state conflict - e.g. creating a book
+// whose ID already exists - carrying the book state that caused it.
+func (BookControllerResponse) Conflict(c *gin.Context, reason string, book store.Book) {
+	write(c, http.StatusConflict, Envelope{
+		Code: CodeConflict, Message: reason, RequestID: requestID(c), Book: &book,
+	})
+}
+
+// Timeout reports that a request gave up waiting (e.g. for a librarian
+// worker) before it could complete.
+func (BookControllerResponse) Timeout(c *gin.Context, reason string) {
+	write(c, http.StatusGatewayTimeout, Envelope{
+		Code: CodeTimeout, Message: reason, RequestID: requestID(c),
+	})
+}
+
+// Internal reports an unexpected error from a dependency (store, etc).
+func (BookControllerResponse) Internal(c *gin.Context, err error) {
+	write(c, http.StatusInternalServerError, Envelope{
+		Code: CodeInternal, Message: err.Error(), RequestID: requestID(c),
+	})
+}
+
+// InsertSuccess reports a newly created book.
+func (BookControllerResponse) InsertSuccess(c *gin.Context, book store.Book) {
+	write(c, http.StatusCreated, Envelope{
+		Code: CodeCreated, Message: "book created", RequestID: requestID(c), Book: &book,
+	})
+}
+
+// OK reports a single book returned by a successful read, update, checkout,
+// or return.
+func (BookControllerResponse) OK(c *gin.Context, message string, book store.Book) {
+	write(c, http.StatusOK, Envelope{
+		Code: CodeOK, Message: message, RequestID: requestID(c), Book: &book,
+	})
+}
+
+// List reports a successful listing of every book.
+func (BookControllerResponse) List(c *gin.Context, books []store.Book) {
+	write(c, http.StatusOK, Envelope{
+		Code: CodeOK, Message: "books listed", RequestID: requestID(c), Books: books,
+	})
+}
+
+// Events reports a successful listing of book events, whether scoped to one
+// book or queried across all of them.
+func (BookControllerResponse) Events(c *gin.Context, evts []events.BookEvent) {
+	write(c, http.StatusOK, Envelope{
+		Code: CodeOK, Message: "events listed", RequestID: requestID(c), Events: evts,
+	})
+}
+
+// Workers reports the pool's current busy/idle snapshot, for GET /librarians.
+func (BookControllerResponse) Workers(c *gin.Context, workers []librarian.WorkerStatus) {
+	write(c, http.StatusOK, Envelope{
+		Code: CodeOK, Message: "librarians listed", RequestID: requestID(c), Workers: workers,
+	})
+}
+
+// Deleted reports a successful deletion - no body, matching the existing
+// 204 No Content contract.
+func (BookControllerResponse) Deleted(c *gin.Context) {
+	c.Status(http.StatusNoContent)
+}
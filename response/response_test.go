@@ -0,0 +1,158 @@
+package response
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/abdullah-hamada7/Go-REST-API/store"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestContext returns a *gin.Context writing into a ResponseRecorder,
+// with the request's Accept header set to format so write
+// negotiates the representation under test.
+func newTestContext(format string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if format != "" {
+		req.Header.Set("Accept", format)
+	}
+	c.Request = req
+	c.Set("request_id", "req-test-1")
+	return c, w
+}
+
+func TestOKEnvelopeShapeJSON(t *testing.T) {
+	c, w := newTestContext("application/json")
+	book := store.Book{ID: "1", Title: "Book One", Quantity: 2}
+
+	Default.OK(c, "book fetched", book)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v (body %q)", err, w.Body.String())
+	}
+	if env.Code != CodeOK {
+		t.Errorf("Code = %q, want %q", env.Code, CodeOK)
+	}
+	if env.RequestID != "req-test-1" {
+		t.Errorf("RequestID = %q, want %q", env.RequestID, "req-test-1")
+	}
+	if env.Book == nil || env.Book.ID != "1" {
+		t.Errorf("Book = %+v, want ID 1", env.Book)
+	}
+	if env.Books != nil {
+		t.Errorf("Books = %+v, want nil for a single-book envelope", env.Books)
+	}
+}
+
+func TestOKEnvelopeShapeXML(t *testing.T) {
+	c, w := newTestContext("application/xml")
+	book := store.Book{ID: "1", Title: "Book One", Quantity: 2}
+
+	Default.OK(c, "book fetched", book)
+
+	if ct := w.Header().Get("Content-Type"); !strings.Contains(ct, "xml") {
+		t.Errorf("Content-Type = %q, want xml", ct)
+	}
+
+	// Assert on the raw body, not just a round-tripped Unmarshal: Unmarshal
+	// would still succeed even if Marshal emitted stray empty <books>,
+	// <events>, or <ratings> elements (encoding/xml silently ignores
+	// unknown/empty elements on decode), which is exactly the bug a
+	// nested-path omitempty tag produces.
+	body := w.Body.String()
+	for _, unwanted := range []string{"<books>", "<events>", "<ratings>"} {
+		if strings.Contains(body, unwanted) {
+			t.Errorf("body contains stray %s for a single-book response with no ratings: %q", unwanted, body)
+		}
+	}
+
+	var env Envelope
+	if err := xml.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v (body %q)", err, w.Body.String())
+	}
+	if env.Code != CodeOK {
+		t.Errorf("Code = %q, want %q", env.Code, CodeOK)
+	}
+	if env.Book == nil || env.Book.ID != "1" {
+		t.Errorf("Book = %+v, want ID 1", env.Book)
+	}
+}
+
+func TestListEnvelopeShapeText(t *testing.T) {
+	c, w := newTestContext("text/plain")
+
+	Default.List(c, []store.Book{{ID: "1", Title: "Book One"}, {ID: "2", Title: "Book Two"}})
+
+	body := w.Body.String()
+	if !strings.Contains(body, "books listed") || !strings.Contains(body, string(CodeOK)) {
+		t.Errorf("text body = %q, want it to mention the message and code", body)
+	}
+}
+
+func TestInvalidMessageUnwrapsValidationErrors(t *testing.T) {
+	c, w := newTestContext("application/json")
+
+	type input struct {
+		Title string `validate:"required,min=3"`
+	}
+	err := validator.New().Struct(input{Title: "a"})
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+
+	Default.InvalidMessage(c, err)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v (body %q)", err, w.Body.String())
+	}
+	if env.Code != CodeValidationFailed {
+		t.Errorf("Code = %q, want %q", env.Code, CodeValidationFailed)
+	}
+	details, ok := env.Details.([]interface{})
+	if !ok || len(details) == 0 {
+		t.Fatalf("Details = %#v, want a non-empty per-field slice", env.Details)
+	}
+}
+
+func TestInvalidMessagePlainError(t *testing.T) {
+	c, w := newTestContext("application/json")
+
+	Default.InvalidMessage(c, errPlain("malformed body"))
+
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("unmarshal: %v (body %q)", err, w.Body.String())
+	}
+	if env.Message != "malformed body" {
+		t.Errorf("Message = %q, want the plain error text unchanged", env.Message)
+	}
+	if env.Details != nil {
+		t.Errorf("Details = %#v, want nil for a non-validator error", env.Details)
+	}
+}
+
+type errPlain string
+
+func (e errPlain) Error() string { return string(e) }
@@ -0,0 +1,221 @@
+package store
+
+import "sync"
+
+// memoryProvider is the default backend - the same seed data the API has
+// always shipped with, now kept in a sync.Map instead of a bare slice so
+// concurrent handlers can't race on it.
+const memoryProvider = "memory"
+
+func init() {
+	Register(memoryProvider, newMemoryStore)
+}
+
+// memoryStore holds books in a sync.Map keyed by ID, plus an orderList
+// tracking insertion order so GetAll keeps returning books in a stable
+// order (sync.Map itself has no defined iteration order). mu serializes
+// every read-modify-write against a book - Checkout/Return (Book carries a
+// Ratings slice, which isn't comparable, so sync.Map.CompareAndSwap can't
+// serialize them) and Update/Patch, which would otherwise race against a
+// concurrent Checkout/Return and silently drop one side's write.
+type memoryStore struct {
+	books *sync.Map
+	order *orderList
+	mu    sync.Mutex
+}
+
+// newMemoryStore is the Provider for the "memory" backend. dsn is ignored -
+// there's nothing to connect to.
+func newMemoryStore(dsn string) (Store, error) {
+	s := newEmptyMemoryStore()
+	for _, b := range seedBooks() {
+		s.books.Store(b.ID, b)
+		s.order.add(b.ID)
+	}
+	return s, nil
+}
+
+// NewMemoryWithoutSeed returns a memory Store with no seed data, for
+// callers that are about to populate it themselves - namely main's replay
+// path, where the usual demo seedBooks would otherwise reappear as
+// phantom inventory with no corresponding audit event every time the
+// process restarts with EVENT_LOG_FILE set.
+func NewMemoryWithoutSeed() Store {
+	return newEmptyMemoryStore()
+}
+
+func newEmptyMemoryStore() *memoryStore {
+	return &memoryStore{books: &sync.Map{}, order: newOrderList()}
+}
+
+func seedBooks() []Book {
+	return []Book{
+		{ID: "1", Title: "Book One", Author: ptr("Author One"), Quantity: 1},
+		{ID: "2", Title: "Book Two", Author: ptr("Author Two"), Quantity: 2},
+		{ID: "3", Title: "Book Three", Author: ptr("Author Three"), Quantity: 3},
+	}
+}
+
+func ptr(s string) *string { return &s }
+
+// Create stores a new book, guarded by mu so it can't race a concurrent
+// Delete on the same id (see mu's doc comment).
+func (s *memoryStore) Create(b Book) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, loaded := s.books.LoadOrStore(b.ID, b); loaded {
+		return Book{}, ErrExists
+	}
+	s.order.add(b.ID)
+	return b, nil
+}
+
+func (s *memoryStore) Get(id string) (Book, error) {
+	v, ok := s.books.Load(id)
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	return v.(Book), nil
+}
+
+func (s *memoryStore) GetAll() ([]Book, error) {
+	ids := s.order.snapshot()
+	out := make([]Book, 0, len(ids))
+	for _, id := range ids {
+		if v, ok := s.books.Load(id); ok {
+			out = append(out, v.(Book))
+		}
+	}
+	return out, nil
+}
+
+// Update replaces the book in place, guarded by mu so it can't race a
+// concurrent Checkout/Return's read-modify-write.
+func (s *memoryStore) Update(id string, b Book) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.books.Load(id); !ok {
+		return Book{}, ErrNotFound
+	}
+	b.ID = id
+	s.books.Store(id, b)
+	return b, nil
+}
+
+// Patch applies only the supplied fields, guarded by mu for the same
+// reason as Update.
+func (s *memoryStore) Patch(id string, patch BookPatch) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.books.Load(id)
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	b := v.(Book)
+	if patch.Title != nil {
+		b.Title = *patch.Title
+	}
+	if patch.Author != nil {
+		b.Author = patch.Author
+	}
+	if patch.Quantity != nil {
+		b.Quantity = *patch.Quantity
+	}
+	if patch.Ratings != nil {
+		b.Ratings = *patch.Ratings
+	}
+	s.books.Store(id, b)
+	return b, nil
+}
+
+// Delete removes a book, guarded by mu for its full duration so it can't
+// interleave with a concurrent Checkout/Update/Patch/Return's read-modify-
+// write and resurrect a book those calls are mid-way through writing back.
+func (s *memoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.books.LoadAndDelete(id); !ok {
+		return ErrNotFound
+	}
+	s.order.remove(id)
+	return nil
+}
+
+// Checkout decrements quantity by one, failing with ErrOutOfStock if none
+// are left. mu serializes the read-modify-write against other
+// Checkout/Return calls, so two concurrent checkouts can no longer both
+// observe Quantity==1 and both decrement (the old "read-then-write-with-
+// no-lock" bug).
+func (s *memoryStore) Checkout(id string) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.books.Load(id)
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	b := v.(Book)
+	if b.Quantity <= 0 {
+		return b, ErrOutOfStock
+	}
+	b.Quantity--
+	s.books.Store(id, b)
+	return b, nil
+}
+
+// Return increments quantity by one, guarded by the same mutex as
+// Checkout so concurrent returns can't clobber each other.
+func (s *memoryStore) Return(id string) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.books.Load(id)
+	if !ok {
+		return Book{}, ErrNotFound
+	}
+	b := v.(Book)
+	b.Quantity++
+	s.books.Store(id, b)
+	return b, nil
+}
+
+// orderList is a small mutex-protected slice of IDs used only to give
+// GetAll a stable, insertion-ordered result. sync.Map purposefully has no
+// iteration order guarantee, so this is kept separate from book storage.
+type orderList struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func newOrderList() *orderList {
+	return &orderList{}
+}
+
+func (o *orderList) add(id string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.ids = append(o.ids, id)
+}
+
+func (o *orderList) remove(id string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, v := range o.ids {
+		if v == id {
+			o.ids = append(o.ids[:i], o.ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *orderList) snapshot() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	out := make([]string, len(o.ids))
+	copy(out, o.ids)
+	return out
+}
@@ -0,0 +1,265 @@
+//go:build cgo
+
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3" // database/sql driver, registered as "sqlite3"
+)
+
+const sqliteProvider = "sqlite"
+
+func init() {
+	Register(sqliteProvider, newSQLiteStore)
+}
+
+// sqliteMigration creates the books table if it doesn't already exist. It's
+// intentionally idempotent and re-run on every startup instead of relying on
+// a separate migration tool.
+const sqliteMigration = `
+CREATE TABLE IF NOT EXISTS books (
+	id       TEXT PRIMARY KEY,
+	title    TEXT NOT NULL,
+	author   TEXT,
+	quantity INTEGER NOT NULL,
+	ratings  TEXT
+);
+`
+
+// sqlStore backs onto database/sql and is shared by the sqlite and postgres
+// providers - they differ only in driver name, placeholder style, and the
+// column GetAll orders by.
+type sqlStore struct {
+	db *sql.DB
+	// placeholder renders the n-th (1-based) bind parameter for this
+	// driver's placeholder syntax ("?" for sqlite, "$1" for postgres).
+	placeholder func(n int) string
+	// orderBy is the column GetAll sorts by for a stable result - "rowid"
+	// for sqlite (insertion order); Postgres has no rowid equivalent, so it
+	// orders by "id" instead.
+	orderBy string
+}
+
+// newSQLiteStore is the Provider for the "sqlite" backend. dsn is a
+// filename (or ":memory:") passed straight to the driver.
+func newSQLiteStore(dsn string) (Store, error) {
+	if dsn == "" {
+		dsn = "books.db"
+	}
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("store: open sqlite: %w", err)
+	}
+	// SQLite allows only one writer at a time regardless of how many
+	// connections database/sql opens, so a pool bigger than one just means
+	// concurrent writers collide and fail with "database is locked" instead
+	// of queuing. Pin the pool to a single connection and give SQLite's own
+	// lock wait a generous timeout as a second line of defense.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
+		return nil, fmt.Errorf("store: set busy_timeout: %w", err)
+	}
+	if _, err := db.Exec(sqliteMigration); err != nil {
+		return nil, fmt.Errorf("store: migrate sqlite: %w", err)
+	}
+	return &sqlStore{db: db, placeholder: questionPlaceholder, orderBy: "rowid"}, nil
+}
+
+func questionPlaceholder(int) string { return "?" }
+
+func (s *sqlStore) Create(b Book) (Book, error) {
+	q := fmt.Sprintf("INSERT INTO books (id, title, author, quantity, ratings) VALUES (%s, %s, %s, %s, %s)",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5))
+	if _, err := s.db.Exec(q, b.ID, b.Title, b.Author, b.Quantity, ratingsToColumn(b.Ratings)); err != nil {
+		// A failed insert for an id that already exists is a primary-key
+		// conflict; report it as the shared sentinel like the memory store.
+		if _, getErr := s.Get(b.ID); getErr == nil {
+			return Book{}, ErrExists
+		}
+		return Book{}, fmt.Errorf("store: create: %w", err)
+	}
+	return b, nil
+}
+
+func (s *sqlStore) Get(id string) (Book, error) {
+	q := fmt.Sprintf("SELECT id, title, author, quantity, ratings FROM books WHERE id = %s", s.placeholder(1))
+	row := s.db.QueryRow(q, id)
+	return scanBook(row)
+}
+
+func (s *sqlStore) GetAll() ([]Book, error) {
+	rows, err := s.db.Query("SELECT id, title, author, quantity, ratings FROM books ORDER BY " + s.orderBy)
+	if err != nil {
+		return nil, fmt.Errorf("store: get all: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Book
+	for rows.Next() {
+		b, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlStore) Update(id string, b Book) (Book, error) {
+	q := fmt.Sprintf("UPDATE books SET title = %s, author = %s, quantity = %s, ratings = %s WHERE id = %s",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3), s.placeholder(4), s.placeholder(5))
+	res, err := s.db.Exec(q, b.Title, b.Author, b.Quantity, ratingsToColumn(b.Ratings), id)
+	if err != nil {
+		return Book{}, fmt.Errorf("store: update: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return Book{}, ErrNotFound
+	}
+	b.ID = id
+	return b, nil
+}
+
+func (s *sqlStore) Patch(id string, patch BookPatch) (Book, error) {
+	b, err := s.Get(id)
+	if err != nil {
+		return Book{}, err
+	}
+	if patch.Title != nil {
+		b.Title = *patch.Title
+	}
+	if patch.Author != nil {
+		b.Author = patch.Author
+	}
+	if patch.Quantity != nil {
+		b.Quantity = *patch.Quantity
+	}
+	if patch.Ratings != nil {
+		b.Ratings = *patch.Ratings
+	}
+	return s.Update(id, b)
+}
+
+func (s *sqlStore) Delete(id string) error {
+	q := fmt.Sprintf("DELETE FROM books WHERE id = %s", s.placeholder(1))
+	res, err := s.db.Exec(q, id)
+	if err != nil {
+		return fmt.Errorf("store: delete: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Checkout and Return run inside a transaction so the read-modify-write of
+// quantity is atomic even though database/sql gives us no compare-and-swap
+// primitive - the row lock taken by the UPDATE serializes concurrent
+// callers.
+func (s *sqlStore) Checkout(id string) (Book, error) {
+	return s.adjustQuantity(id, -1, ErrOutOfStock)
+}
+
+func (s *sqlStore) Return(id string) (Book, error) {
+	return s.adjustQuantity(id, +1, nil)
+}
+
+// adjustQuantity applies delta to the named book's quantity as a single
+// atomic "UPDATE ... WHERE quantity + delta >= 0" statement instead of a
+// separate SELECT followed by UPDATE. A SELECT-then-UPDATE only serializes
+// once the UPDATE's row lock is taken, so under READ COMMITTED two
+// concurrent transactions can both read the same quantity, both decide
+// it's safe to decrement, and both commit - a lost update. Folding the
+// guard into the UPDATE's WHERE clause means the database itself rejects
+// the second writer instead of both succeeding.
+func (s *sqlStore) adjustQuantity(id string, delta int, guardErr error) (Book, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return Book{}, fmt.Errorf("store: begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	upd := fmt.Sprintf("UPDATE books SET quantity = quantity + %s WHERE id = %s AND quantity + %s >= 0",
+		s.placeholder(1), s.placeholder(2), s.placeholder(3))
+	res, err := tx.Exec(upd, delta, id, delta)
+	if err != nil {
+		return Book{}, fmt.Errorf("store: update quantity: %w", err)
+	}
+
+	q := fmt.Sprintf("SELECT id, title, author, quantity, ratings FROM books WHERE id = %s", s.placeholder(1))
+	b, err := scanBook(tx.QueryRow(q, id))
+	if err != nil {
+		return Book{}, err
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		// The SELECT above already turned a missing id into ErrNotFound,
+		// so reaching here means the book exists and the WHERE guard
+		// rejected the update - the delta would have driven quantity
+		// negative.
+		if guardErr != nil {
+			return b, guardErr
+		}
+		return Book{}, fmt.Errorf("store: update quantity: no rows affected")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Book{}, fmt.Errorf("store: commit: %w", err)
+	}
+	return b, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows so scanBook can be
+// shared between Get (single row) and GetAll (row set).
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBook(r rowScanner) (Book, error) {
+	var b Book
+	var author, ratings sql.NullString
+	if err := r.Scan(&b.ID, &b.Title, &author, &b.Quantity, &ratings); err != nil {
+		if err == sql.ErrNoRows {
+			return Book{}, ErrNotFound
+		}
+		return Book{}, fmt.Errorf("store: scan: %w", err)
+	}
+	if author.Valid {
+		b.Author = &author.String
+	}
+	b.Ratings = ratingsFromColumn(ratings.String)
+	return b, nil
+}
+
+// ratingsToColumn renders ratings as a comma-separated list for the TEXT
+// column both migrations store them in - simpler than a second table for a
+// field that's just a handful of ints.
+func ratingsToColumn(ratings []int) string {
+	if len(ratings) == 0 {
+		return ""
+	}
+	parts := make([]string, len(ratings))
+	for i, r := range ratings {
+		parts[i] = strconv.Itoa(r)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ratingsFromColumn parses the column ratingsToColumn produces, ignoring
+// any value that isn't a valid int rather than failing the whole read.
+func ratingsFromColumn(s string) []int {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]int, 0, len(parts))
+	for _, p := range parts {
+		if n, err := strconv.Atoi(p); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+//go:build cgo
+
+package store
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSQLStoreConcurrentCheckoutsNoOversubscription fires concurrent
+// Checkout/Return calls directly at a sqlite-backed sqlStore and asserts
+// the same no-oversubscription invariant the memory/pool test checks,
+// guarding the atomic "UPDATE ... WHERE quantity + delta >= 0" in
+// adjustQuantity against a regression back to a racy SELECT-then-UPDATE.
+func TestSQLStoreConcurrentCheckoutsNoOversubscription(t *testing.T) {
+	const initialQuantity = 50
+	const attempts = 300
+
+	s, err := New(sqliteProvider, ":memory:")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := s.Create(Book{ID: "concurrent", Title: "Concurrency 101", Quantity: initialQuantity}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		checkedOut int
+		outOfStock int
+	)
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, err := s.Checkout("concurrent")
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				checkedOut++
+				if b.Quantity < 0 {
+					t.Error("book quantity went negative under concurrent checkout")
+				}
+			case ErrOutOfStock:
+				outOfStock++
+			default:
+				t.Errorf("Checkout: unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Return half of what was checked out, so the final tally exercises
+	// both Checkout and Return under the same concurrency.
+	toReturn := checkedOut / 2
+	var returned int
+	wg.Add(toReturn)
+	for i := 0; i < toReturn; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := s.Return("concurrent"); err == nil {
+				mu.Lock()
+				returned++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if checkedOut+outOfStock != attempts {
+		t.Fatalf("checkedOut(%d) + outOfStock(%d) = %d, want %d", checkedOut, outOfStock, checkedOut+outOfStock, attempts)
+	}
+
+	final, err := s.Get("concurrent")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if final.Quantity < 0 {
+		t.Fatalf("final quantity went negative: %d", final.Quantity)
+	}
+	if want := initialQuantity - (checkedOut - returned); final.Quantity != want {
+		t.Fatalf("final quantity = %d, want %d (initial %d - outstanding %d)", final.Quantity, want, initialQuantity, checkedOut-returned)
+	}
+}
+
+// TestSQLStoreGetAll checks that GetAll on a sqlite-backed sqlStore (which
+// orders by its configured orderBy column) returns every book created. It
+// runs against sqlite only, so it does not exercise the postgres ordering.
+func TestSQLStoreGetAll(t *testing.T) {
+	s, err := New(sqliteProvider, ":memory:")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if _, err := s.Create(Book{ID: id, Title: "Book " + id, Quantity: 1}); err != nil {
+			t.Fatalf("Create(%s): %v", id, err)
+		}
+	}
+
+	got, err := s.GetAll()
+	if err != nil {
+		t.Fatalf("GetAll: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("GetAll returned %d books, want 3", len(got))
+	}
+}
+
+// TestSQLStoreRatingsRoundTrip guards the ratings column added for chunk0-4
+// - a book's ratings should survive a Create followed by a Get, not come
+// back nil.
+func TestSQLStoreRatingsRoundTrip(t *testing.T) {
+	s, err := New(sqliteProvider, ":memory:")
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := s.Create(Book{ID: "rated", Title: "Rated Book", Quantity: 1, Ratings: []int{5, 4, 3}}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, err := s.Get("rated")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := []int{5, 4, 3}
+	if len(got.Ratings) != len(want) {
+		t.Fatalf("Ratings = %v, want %v", got.Ratings, want)
+	}
+	for i := range want {
+		if got.Ratings[i] != want[i] {
+			t.Fatalf("Ratings = %v, want %v", got.Ratings, want)
+		}
+	}
+}
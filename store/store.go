@@ -0,0 +1,142 @@
+// Package store defines the pluggable storage abstraction used by the API.
+//
+// Handlers never talk to a database (or a slice) directly - they depend on
+// the Store interface below, and a concrete backend is selected at startup
+// by name (see New). This keeps the HTTP layer free of SQL/driver details
+// and lets tests substitute a fake Store instead of spinning up real
+// infrastructure.
+package store
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Book is the canonical representation of a book as persisted by a Store.
+// It mirrors the JSON shape the API has always returned; the xml tags
+// support the API's XML representation (see write in the response package) -
+// ID is rendered as an attribute and Ratings as a nested list.
+type Book struct {
+	XMLName  xml.Name `json:"-" xml:"book"`
+	ID       string   `json:"id" xml:"id,attr"`
+	Title    string   `json:"title" xml:"title"`
+	Author   *string  `json:"author" xml:"author"`
+	Quantity int      `json:"quantity" xml:"quantity"`
+	Ratings  []int    `json:"ratings,omitempty" xml:"ratings>rating,omitempty"`
+}
+
+// MarshalXML renders Book by hand instead of relying on the struct tags
+// above for the Ratings field: encoding/xml doesn't honor omitempty on a
+// multi-segment tag like "ratings>rating,omitempty", so a book with no
+// ratings would otherwise always carry a stray empty <ratings></ratings>.
+// Decoding still uses the struct tags via the default UnmarshalXML.
+func (b Book) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "book"}
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "id"}, Value: b.ID}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := enc.EncodeElement(b.Title, xml.StartElement{Name: xml.Name{Local: "title"}}); err != nil {
+		return err
+	}
+	if b.Author != nil {
+		if err := enc.EncodeElement(*b.Author, xml.StartElement{Name: xml.Name{Local: "author"}}); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeElement(b.Quantity, xml.StartElement{Name: xml.Name{Local: "quantity"}}); err != nil {
+		return err
+	}
+	if len(b.Ratings) > 0 {
+		wrap := xml.StartElement{Name: xml.Name{Local: "ratings"}}
+		if err := enc.EncodeToken(wrap); err != nil {
+			return err
+		}
+		for _, r := range b.Ratings {
+			if err := enc.EncodeElement(r, xml.StartElement{Name: xml.Name{Local: "rating"}}); err != nil {
+				return err
+			}
+		}
+		if err := enc.EncodeToken(wrap.End()); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// String renders a Book as "[id] Title by Author", used for the API's
+// text/plain representation.
+func (b Book) String() string {
+	author := "unknown"
+	if b.Author != nil {
+		author = *b.Author
+	}
+	return fmt.Sprintf("[%s] %s by %s", b.ID, b.Title, author)
+}
+
+// BookPatch carries only the fields a PATCH request actually supplied.
+// A nil field means "leave as-is".
+type BookPatch struct {
+	Title    *string
+	Author   *string
+	Quantity *int
+	Ratings  *[]int
+}
+
+// Sentinel errors returned by every Store implementation, so handlers can
+// branch on error identity instead of matching strings.
+var (
+	ErrNotFound   = errors.New("store: book not found")
+	ErrOutOfStock = errors.New("store: book out of stock")
+	ErrExists     = errors.New("store: book already exists")
+)
+
+// Store is the behavior every storage backend must provide. Implementations
+// are responsible for their own concurrency safety - callers may invoke
+// these methods from multiple goroutines at once.
+type Store interface {
+	Create(b Book) (Book, error)
+	Get(id string) (Book, error)
+	GetAll() ([]Book, error)
+	Update(id string, b Book) (Book, error)
+	Patch(id string, patch BookPatch) (Book, error)
+	Delete(id string) error
+	Checkout(id string) (Book, error)
+	Return(id string) (Book, error)
+}
+
+// Provider constructs a Store from a backend-specific DSN. Backends that
+// don't need one (like the in-memory store) simply ignore it.
+type Provider func(dsn string) (Store, error)
+
+var providers = map[string]Provider{}
+
+// Register makes a storage backend available under name, so it can later be
+// selected by New. Providers are expected to call this from an init
+// function in their own file, e.g. Register("sqlite", newSQLiteStore).
+func Register(name string, p Provider) {
+	if _, exists := providers[name]; exists {
+		panic(fmt.Sprintf("store: provider %q already registered", name))
+	}
+	providers[name] = p
+}
+
+// New builds the Store registered under name, passing dsn through to its
+// provider. main reads name from the STORE_PROVIDER env var at startup;
+// there is no command-line flag for it.
+func New(name, dsn string) (Store, error) {
+	p, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("store: unknown provider %q (known: %v)", name, knownProviders())
+	}
+	return p(dsn)
+}
+
+func knownProviders() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	return names
+}